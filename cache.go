@@ -0,0 +1,188 @@
+package form
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// planStep is the precomputed recipe for reaching and rendering a single
+// field of a cached type, in declaration order. Most steps are ordinary
+// leaves; a step with Dynamic set instead describes a slice/array/map
+// field, whose elements can't be precomputed because their count depends
+// on the live data.
+type planStep struct {
+	Index         []int // path from the root struct to this field, for FieldByIndex
+	ReceiverIndex []int // path to the struct declaring OptionsMethod, if any
+	OptionsMethod string
+	Name          string
+	Label         string
+	Placeholder   string
+	Type          string
+	Tags          map[string]string
+	Dynamic       bool // true for a slice/array/map field; see mapFields/sliceFields
+	IsMap         bool // only meaningful when Dynamic
+}
+
+type typePlan struct {
+	Steps []planStep
+}
+
+// cacheKey identifies a plan by both the struct type and the tag name it
+// was built from - two Renderers with different TagName values need
+// different plans for the same type.
+type cacheKey struct {
+	t   reflect.Type
+	tag string
+}
+
+var typeCache sync.Map // map[cacheKey]*typePlan
+
+func (r *Renderer) planType(t reflect.Type) *typePlan {
+	key := cacheKey{t, r.TagName}
+	if p, ok := typeCache.Load(key); ok {
+		return p.(*typePlan)
+	}
+	plan := &typePlan{Steps: r.buildPlan(t, nil, nil)}
+	// Two callers racing to build the same plan both do the work and
+	// both get a correct, equivalent result; LoadOrStore just makes sure
+	// everyone ends up sharing one of them afterwards.
+	actual, _ := typeCache.LoadOrStore(key, plan)
+	return actual.(*typePlan)
+}
+
+// buildPlan walks t's fields in declaration order and returns one step per
+// field, preserving that order - including collection fields, so a
+// template relying on declaration order (e.g. a scalar before and after a
+// slice field) renders the same as it did before plans were cached.
+func (r *Renderer) buildPlan(t reflect.Type, index []int, names []string) []planStep {
+	var steps []planStep
+
+	for _, tf := range reflect.VisibleFields(t) {
+		if !tf.IsExported() {
+			continue
+		}
+		idx := append(append([]int{}, index...), tf.Index...)
+
+		typ := tf.Type
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() == reflect.Struct && typ != timeType {
+			if !tf.Anonymous {
+				steps = append(steps, r.buildPlan(typ, idx, append(names, tf.Name))...)
+			}
+			continue
+		}
+
+		isCollection := typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map
+		tags, ignored := parseTags(tf.Tag.Get(r.TagName))
+		if ignored {
+			continue
+		}
+
+		if isCollection && typ != bytesType {
+			name := tf.Name
+			if v, ok := tags["name"]; ok {
+				name = v
+			}
+			steps = append(steps, planStep{
+				Index:   idx,
+				Name:    strings.Join(append(names, name), "."),
+				Tags:    tags,
+				Dynamic: true,
+				IsMap:   typ.Kind() == reflect.Map,
+			})
+			continue
+		}
+
+		methodName := tf.Name + "Options"
+		hasMethod := false
+		if _, ok := t.MethodByName(methodName); ok {
+			hasMethod = true
+		} else if _, ok := reflect.PointerTo(t).MethodByName(methodName); ok {
+			hasMethod = true
+		}
+
+		step := planStep{
+			Index:       idx,
+			Name:        strings.Join(append(names, tf.Name), "."),
+			Label:       tf.Name,
+			Placeholder: tf.Name,
+			Type:        defaultType(typ),
+			Tags:        tags,
+		}
+		if hasMethod {
+			step.ReceiverIndex = index
+			step.OptionsMethod = methodName
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// fieldByIndexSafe walks rv through index the way reflect.Value.FieldByIndex
+// does, except that a nil pointer along the way is substituted with its
+// zero value instead of panicking (mirroring the trick in valueOf).
+// Whether an intermediate is nil is a property of the data, not the
+// type, so this is checked on every call rather than baked into the plan.
+func fieldByIndexSafe(rv reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				rv = reflect.Zero(rv.Type().Elem())
+			} else {
+				rv = rv.Elem()
+			}
+		}
+		rv = rv.Field(i)
+	}
+	return rv
+}
+
+// renderValue turns rv into fields using its cached plan, prepending
+// names so nested calls (from a slice/map element) still get the right
+// dotted prefix. Steps are rendered in plan order, which mirrors rv's
+// declaration order, so a collection field interleaved with ordinary
+// fields still renders in the right place.
+func (r *Renderer) renderValue(rv reflect.Value, names []string) []field {
+	plan := r.planType(rv.Type())
+	ret := make([]field, 0, len(plan.Steps))
+
+	for _, step := range plan.Steps {
+		fv := fieldByIndexSafe(rv, step.Index)
+
+		if step.Dynamic {
+			if step.IsMap {
+				ret = append(ret, r.mapFields(fv, step.Name, names)...)
+			} else {
+				ret = append(ret, r.sliceFields(fv, step.Name, step.Tags, names)...)
+			}
+			continue
+		}
+
+		f := field{
+			Name:        joinNames(names, step.Name),
+			Label:       step.Label,
+			Placeholder: step.Placeholder,
+			Type:        step.Type,
+			Value:       fv.Interface(),
+		}
+		if step.OptionsMethod != "" {
+			receiver := fieldByIndexSafe(rv, step.ReceiverIndex)
+			f.Choices = discoverOptions(receiver, step.Name[strings.LastIndex(step.Name, ".")+1:])
+		}
+		r.applyTags(&f, step.Tags)
+		ret = append(ret, f)
+	}
+
+	return ret
+}
+
+func joinNames(prefix []string, rel string) string {
+	if len(prefix) == 0 {
+		return rel
+	}
+	return strings.Join(prefix, ".") + "." + rel
+}
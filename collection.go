@@ -0,0 +1,75 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sliceFields renders one set of fields per element of rv, which must be
+// a slice or array. Elements are named with a bracketed index segment
+// appended to name (e.g. "Addresses[0]"). A min= tag pads a short
+// slice/array with blank rows so the rendered form has room to add new
+// entries.
+func (r *Renderer) sliceFields(rv reflect.Value, name string, tags map[string]string, names []string) []field {
+	minRows := 0
+	if v, ok := tags["min"]; ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			minRows = n
+		}
+	}
+
+	length := rv.Len()
+	rows := length
+	if minRows > rows {
+		rows = minRows
+	}
+
+	elemType := rv.Type().Elem()
+	ret := make([]field, 0, rows)
+	for i := 0; i < rows; i++ {
+		elem := reflect.Zero(elemType)
+		if i < length {
+			elem = rv.Index(i)
+		}
+		segment := fmt.Sprintf("%s[%d]", name, i)
+		ret = append(ret, r.elementFields(elem, segment, names)...)
+	}
+	return ret
+}
+
+// mapFields renders one set of fields per entry of rv, named with a
+// bracketed key segment appended to name (e.g. "Tags[key]").
+func (r *Renderer) mapFields(rv reflect.Value, name string, names []string) []field {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	ret := make([]field, 0, len(keys))
+	for _, key := range keys {
+		segment := fmt.Sprintf("%s[%v]", name, key.Interface())
+		ret = append(ret, r.elementFields(rv.MapIndex(key), segment, names)...)
+	}
+	return ret
+}
+
+// elementFields renders a single slice/array/map element: structs recurse
+// through r.fields() with segment appended as the next name component,
+// anything else becomes a single leaf field named by segment.
+func (r *Renderer) elementFields(elem reflect.Value, segment string, names []string) []field {
+	if reflect.Indirect(elem).Kind() == reflect.Struct {
+		return r.fields(elem.Interface(), append(names, segment)...)
+	}
+
+	name := append(names, segment)
+	return []field{{
+		Name:        strings.Join(name, "."),
+		Label:       segment,
+		Placeholder: segment,
+		Type:        "text",
+		Value:       elem.Interface(),
+	}}
+}
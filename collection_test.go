@@ -0,0 +1,101 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFields_collections(t *testing.T) {
+	type address struct {
+		Street1 string
+	}
+
+	t.Run("slice of structs", func(t *testing.T) {
+		arg := struct {
+			Addresses []address
+		}{
+			Addresses: []address{{"123 Test St"}, {"456 Other Ave"}},
+		}
+		got := fields(arg)
+		want := []field{
+			{Name: "Addresses[0].Street1", Label: "Street1", Placeholder: "Street1", Type: "text", Value: "123 Test St"},
+			{Name: "Addresses[1].Street1", Label: "Street1", Placeholder: "Street1", Type: "text", Value: "456 Other Ave"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("slice of strings", func(t *testing.T) {
+		arg := struct {
+			Tags []string
+		}{
+			Tags: []string{"a", "b"},
+		}
+		got := fields(arg)
+		want := []field{
+			{Name: "Tags[0]", Label: "Tags[0]", Placeholder: "Tags[0]", Type: "text", Value: "a"},
+			{Name: "Tags[1]", Label: "Tags[1]", Placeholder: "Tags[1]", Type: "text", Value: "b"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("min pads short slices with blank rows", func(t *testing.T) {
+		arg := struct {
+			Addresses []address `form:"min=3"`
+		}{
+			Addresses: []address{{"123 Test St"}},
+		}
+		got := fields(arg)
+		if len(got) != 3 {
+			t.Fatalf("got %d fields, want 3", len(got))
+		}
+		if got[0].Value != "123 Test St" || got[1].Value != "" || got[2].Value != "" {
+			t.Errorf("got %+v", got)
+		}
+		if got[1].Name != "Addresses[1].Street1" {
+			t.Errorf("got name %q", got[1].Name)
+		}
+	})
+
+	t.Run("collection field keeps its declaration position", func(t *testing.T) {
+		arg := struct {
+			A    string
+			Tags []string
+			B    string
+		}{
+			A:    "first",
+			Tags: []string{"x", "y"},
+			B:    "last",
+		}
+		got := fields(arg)
+		want := []field{
+			{Name: "A", Label: "A", Placeholder: "A", Type: "text", Value: "first"},
+			{Name: "Tags[0]", Label: "Tags[0]", Placeholder: "Tags[0]", Type: "text", Value: "x"},
+			{Name: "Tags[1]", Label: "Tags[1]", Placeholder: "Tags[1]", Type: "text", Value: "y"},
+			{Name: "B", Label: "B", Placeholder: "B", Type: "text", Value: "last"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("map of strings", func(t *testing.T) {
+		arg := struct {
+			Tags map[string]string
+		}{
+			Tags: map[string]string{"b": "2", "a": "1"},
+		}
+		got := fields(arg)
+		want := []field{
+			{Name: "Tags[a]", Label: "Tags[a]", Placeholder: "Tags[a]", Type: "text", Value: "1"},
+			{Name: "Tags[b]", Label: "Tags[b]", Placeholder: "Tags[b]", Type: "text", Value: "2"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
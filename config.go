@@ -0,0 +1,210 @@
+package form
+
+import (
+	"html/template"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TagHandler mutates f according to value, the raw string found under
+// key in a field's struct tag (e.g. value is "Full Name" for a
+// `form:"label=Full Name"` tag handled by key "label").
+type TagHandler func(f *field, value string)
+
+// Renderer turns struct values into []field using a configurable tag
+// name and an extensible, ordered set of tag handlers. The zero value is
+// not usable; create one with New.
+type Renderer struct {
+	// TagName is the struct tag key inspected for form metadata. Defaults
+	// to "form", so structs that already carry json/db/validate tags
+	// under other keys don't collide with it.
+	TagName string
+
+	order    []string
+	handlers map[string]TagHandler
+}
+
+// RendererOption configures a Renderer built by New.
+type RendererOption func(*Renderer)
+
+// WithTagName overrides the struct tag key a Renderer inspects for form
+// metadata.
+func WithTagName(name string) RendererOption {
+	return func(r *Renderer) { r.TagName = name }
+}
+
+// New creates a Renderer with the built-in tag handlers (name, label,
+// placeholder, type, id, footer, class, readonly, required, options,
+// widget, validate) registered, then applies opts on top.
+func New(opts ...RendererOption) *Renderer {
+	r := &Renderer{
+		TagName:  "form",
+		handlers: make(map[string]TagHandler),
+	}
+	r.RegisterTag("name", handleName)
+	r.RegisterTag("label", handleLabel)
+	r.RegisterTag("placeholder", handlePlaceholder)
+	r.RegisterTag("type", handleType)
+	r.RegisterTag("id", handleID)
+	r.RegisterTag("footer", handleFooter)
+	r.RegisterTag("class", handleClass)
+	r.RegisterTag("readonly", handleReadOnly)
+	r.RegisterTag("required", handleRequired)
+	r.RegisterTag("options", handleOptions)
+	r.RegisterTag("widget", handleWidget)
+	r.RegisterTag("validate", handleValidate)
+
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RegisterTag adds or overrides the handler for tag key on r, so users
+// can add their own tag keys (autocomplete, step, accept, ...) without
+// patching the package. Re-registering an existing key keeps its
+// original position in the application order; a new key is appended to
+// the end. The built-in keys are registered the same way in New, so
+// there is no special case for them.
+func (r *Renderer) RegisterTag(key string, fn TagHandler) {
+	if _, exists := r.handlers[key]; !exists {
+		r.order = append(r.order, key)
+	}
+	r.handlers[key] = fn
+}
+
+// applyTags runs every handler whose key is present in tags, in
+// registration order - not map iteration order, since e.g. label must
+// run before an explicit placeholder= tag can override it.
+func (r *Renderer) applyTags(f *field, tags map[string]string) {
+	for _, key := range r.order {
+		v, ok := tags[key]
+		if !ok {
+			continue
+		}
+		r.handlers[key](f, v)
+	}
+}
+
+// Fields renders v into a slice of template-ready fields using r's tag
+// name and handlers.
+func (r *Renderer) Fields(v interface{}) []field {
+	return r.fields(v)
+}
+
+func (r *Renderer) fields(v interface{}, names ...string) []field {
+	rv := valueOf(v)
+	if rv.Kind() != reflect.Struct {
+		panic("invalid value; only structs are supported")
+	}
+	return r.renderValue(rv, names)
+}
+
+// Warm pre-populates r's reflection cache for each of types, so the
+// first real request doesn't pay the planning cost.
+func (r *Renderer) Warm(types ...any) {
+	for _, v := range types {
+		rv := valueOf(v)
+		if rv.Kind() != reflect.Struct {
+			continue
+		}
+		r.planType(rv.Type())
+	}
+}
+
+var defaultRenderer = New()
+
+// RegisterTag registers fn as the handler for tag key on the package's
+// default Renderer, used by the package-level Fields function.
+func RegisterTag(key string, fn TagHandler) {
+	defaultRenderer.RegisterTag(key, fn)
+}
+
+// Fields renders v into a slice of template-ready fields using the
+// default Renderer (tag name "form").
+func Fields(v interface{}) []field {
+	return defaultRenderer.Fields(v)
+}
+
+// Warm pre-populates the default Renderer's reflection cache for each of
+// types, so the first real request doesn't pay the planning cost.
+func Warm(types ...any) {
+	defaultRenderer.Warm(types...)
+}
+
+func handleName(f *field, v string) { f.Name = v }
+
+func handleLabel(f *field, v string) {
+	f.Label = v
+	// DO NOT register this after the placeholder handler, or this will
+	// stomp an explicit placeholder= tag - handlers run in
+	// registration order, so the order they're added in New matters.
+	f.Placeholder = v
+}
+
+func handlePlaceholder(f *field, v string) { f.Placeholder = v }
+
+func handleType(f *field, v string) { f.Type = v }
+
+func handleID(f *field, v string) { f.ID = v }
+
+func handleFooter(f *field, v string) {
+	// Probably shouldn't be HTML but whatever.
+	f.Footer = template.HTML(v)
+}
+
+func handleClass(f *field, v string) { f.Class = v }
+
+func handleReadOnly(f *field, v string) { f.ReadOnly = v == "true" }
+
+func handleRequired(f *field, v string) { f.Required = v == "true" }
+
+// handleOptions only populates f.Options; it deliberately does not switch
+// f.Type to "select". The options= key predates this package's <select>
+// support and reflect_test.go already pins it to a flat list of HTML
+// attribute-style flags (e.g. `form:"options=readonly,required"` wants
+// Options: []string{"readonly", "required"} with Type left at "text"), so
+// reusing the same key to also mean "these are the <select> choices"
+// would break that contract. widget=select is the explicit, unambiguous
+// way to switch a field's render type.
+func handleOptions(f *field, v string) { f.Options = strings.Split(v, ",") }
+
+func handleWidget(f *field, v string) {
+	switch v {
+	case "radio", "select", "textarea":
+		f.Type = v
+	}
+}
+
+// handleValidate parses a comma-separated validate tag (e.g.
+// "required,min=0,max=120") and records it on f both as the raw rule
+// list used by Validate and as the matching HTML attribute fields so
+// templates can render <input required min="0" max="120">.
+func handleValidate(f *field, v string) {
+	f.Rules = parseRules(v)
+	for _, r := range f.Rules {
+		switch r.Name {
+		case "required":
+			f.Required = true
+		case "min":
+			if n, err := strconv.ParseFloat(r.Arg, 64); err == nil {
+				f.Min = &n
+			}
+		case "max":
+			if n, err := strconv.ParseFloat(r.Arg, 64); err == nil {
+				f.Max = &n
+			}
+		case "minlen":
+			if n, err := strconv.Atoi(r.Arg); err == nil {
+				f.MinLength = &n
+			}
+		case "maxlen":
+			if n, err := strconv.Atoi(r.Arg); err == nil {
+				f.MaxLength = &n
+			}
+		case "pattern":
+			f.Pattern = strings.Trim(r.Arg, "/")
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderer_WithTagName(t *testing.T) {
+	type user struct {
+		Name string `json:"name=Full Name"`
+	}
+
+	r := New(WithTagName("json"))
+	got := r.Fields(user{Name: "Dwight Schrute"})
+	want := []field{
+		{Name: "Full Name", Label: "Name", Placeholder: "Name", Type: "text", Value: "Dwight Schrute"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestRenderer_RegisterTag(t *testing.T) {
+	r := New()
+	r.RegisterTag("autocomplete", func(f *field, v string) { f.Class = v })
+
+	type user struct {
+		Email string `form:"autocomplete=email"`
+	}
+	got := r.Fields(user{Email: "dwight@dundermifflin.com"})
+	if got[0].Class != "email" {
+		t.Errorf("got Class = %q", got[0].Class)
+	}
+}
+
+func TestRenderer_RegisterTag_keepsPosition(t *testing.T) {
+	// Overriding the built-in "label" handler in place still runs before
+	// "placeholder", since RegisterTag keeps an existing key's original
+	// slot rather than moving it to the end.
+	r := New()
+	r.RegisterTag("label", func(f *field, v string) {
+		f.Label = "[" + v + "]"
+		f.Placeholder = "[" + v + "]"
+	})
+
+	type user struct {
+		Name string `form:"label=Name;placeholder=Enter name"`
+	}
+	got := r.Fields(user{})
+	if got[0].Label != "[Name]" || got[0].Placeholder != "Enter name" {
+		t.Errorf("got Label = %q, Placeholder = %q", got[0].Label, got[0].Placeholder)
+	}
+}
+
+func TestFields_defaultRendererUnaffected(t *testing.T) {
+	// Custom Renderers must not leak their handlers/tag name back onto
+	// the package-level default.
+	New(WithTagName("json")).RegisterTag("autocomplete", func(f *field, v string) {})
+
+	type user struct {
+		Name string `form:"name=Full Name"`
+	}
+	got := Fields(user{Name: "Jim Halpert"})
+	want := []field{
+		{Name: "Full Name", Label: "Name", Placeholder: "Name", Type: "text", Value: "Jim Halpert"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
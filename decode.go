@@ -0,0 +1,340 @@
+package form
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError describes why a single named field could not be decoded. The
+// Name matches the dotted convention used by fields() (e.g. "Address.Street1").
+type FieldError struct {
+	Name string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Name, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned by Decode and DecodeValues when one or more
+// fields failed to parse. It collects every failure instead of stopping
+// at the first one, so callers can report all of them at once.
+type DecodeError []*FieldError
+
+func (e DecodeError) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return "form: " + strings.Join(msgs, "; ")
+}
+
+// Decode parses r's form values (calling r.ParseForm if that hasn't
+// happened yet) and populates dst, which must be a non-nil pointer to a
+// struct, using the same dotted-name and tag conventions as fields().
+func Decode(r *http.Request, dst any) error {
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+	return DecodeValues(r.Form, dst)
+}
+
+// DecodeValues populates dst from values using the same dotted-name and
+// tag conventions as fields(): nested structs are addressed with a "."
+// and the name=/- tag overrides are honored. dst must be a non-nil
+// pointer to a struct.
+func DecodeValues(values url.Values, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("form: Decode requires a non-nil pointer, got %T", dst)
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("form: Decode requires a pointer to a struct, got %T", dst)
+	}
+
+	var errs DecodeError
+	decodeStruct(rv, values, nil, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func decodeStruct(rv reflect.Value, values url.Values, names []string, errs *DecodeError) {
+	t := rv.Type()
+	for _, tf := range reflect.VisibleFields(t) {
+		if !tf.IsExported() {
+			continue
+		}
+
+		rf := rv.FieldByIndex(tf.Index)
+		if rf.Kind() == reflect.Pointer {
+			// Mirror the zero-value trick in valueOf, but the other way
+			// around: decoding needs somewhere to write to, so allocate
+			// rather than substitute a throwaway zero value.
+			if rf.IsNil() {
+				rf.Set(reflect.New(rf.Type().Elem()))
+			}
+			rf = rf.Elem()
+		}
+
+		if rf.Kind() == reflect.Struct && rf.Type() != reflect.TypeOf(time.Time{}) {
+			if !tf.Anonymous {
+				decodeStruct(rf, values, append(names, tf.Name), errs)
+			} else {
+				decodeStruct(rf, values, names, errs)
+			}
+			continue
+		}
+
+		isCollection := rf.Kind() == reflect.Slice || rf.Kind() == reflect.Array || rf.Kind() == reflect.Map
+		if isCollection && rf.Type() != bytesType {
+			tags, ignored := parseTags(tf.Tag.Get("form"))
+			if ignored {
+				continue
+			}
+			name := tf.Name
+			if v, ok := tags["name"]; ok {
+				name = v
+			}
+			name = strings.Join(append(names, name), ".")
+			if rf.Kind() == reflect.Map {
+				decodeMap(rf, name, values, errs)
+			} else {
+				decodeSlice(rf, name, values, errs)
+			}
+			continue
+		}
+
+		tags, ignored := parseTags(tf.Tag.Get("form"))
+		if ignored {
+			continue
+		}
+		name := strings.Join(append(names, tf.Name), ".")
+		if v, ok := tags["name"]; ok {
+			name = v
+		}
+
+		raw, ok := values[name]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+		if err := setValue(rf, raw[0], tags["format"]); err != nil {
+			*errs = append(*errs, &FieldError{Name: name, Err: err})
+		}
+	}
+}
+
+// maxDecodeSliceLen caps how large a slice decodeSlice will allocate from
+// a submitted index, so a single malicious "name[999999999]=x" field
+// can't make Decode try to allocate gigabytes for an attacker-chosen,
+// mostly-empty slice.
+const maxDecodeSliceLen = 10000
+
+// decodeSlice reconstructs rf (a slice or array) from submitted keys of
+// the form "name[0]", "name[1]", ... . Arrays are filled in place up to
+// their fixed length; slices are grown to the highest submitted index, up
+// to maxDecodeSliceLen.
+func decodeSlice(rf reflect.Value, name string, values url.Values, errs *DecodeError) {
+	indices := collectIndices(name, values)
+	if len(indices) == 0 {
+		return
+	}
+
+	if rf.Kind() == reflect.Array {
+		for _, i := range indices {
+			if i >= rf.Len() {
+				continue
+			}
+			decodeElement(rf.Index(i), fmt.Sprintf("%s[%d]", name, i), values, errs)
+		}
+		return
+	}
+
+	length := indices[len(indices)-1] + 1
+	if length > maxDecodeSliceLen {
+		*errs = append(*errs, &FieldError{
+			Name: name,
+			Err:  fmt.Errorf("index %d exceeds the maximum slice length of %d", length-1, maxDecodeSliceLen),
+		})
+		return
+	}
+
+	slice := reflect.MakeSlice(rf.Type(), length, length)
+	for _, i := range indices {
+		decodeElement(slice.Index(i), fmt.Sprintf("%s[%d]", name, i), values, errs)
+	}
+	rf.Set(slice)
+}
+
+// decodeMap reconstructs rf (a map) from submitted keys of the form
+// "name[key]", "name[other]", ... .
+func decodeMap(rf reflect.Value, name string, values url.Values, errs *DecodeError) {
+	keys := collectMapKeys(name, values)
+	if len(keys) == 0 {
+		return
+	}
+
+	keyType := rf.Type().Key()
+	elemType := rf.Type().Elem()
+	m := reflect.MakeMapWithSize(rf.Type(), len(keys))
+	for _, k := range keys {
+		segment := fmt.Sprintf("%s[%s]", name, k)
+
+		keyVal := reflect.New(keyType).Elem()
+		if err := setValue(keyVal, k, ""); err != nil {
+			*errs = append(*errs, &FieldError{Name: segment, Err: err})
+			continue
+		}
+
+		elem := reflect.New(elemType).Elem()
+		decodeElement(elem, segment, values, errs)
+		m.SetMapIndex(keyVal, elem)
+	}
+	rf.Set(m)
+}
+
+// decodeElement decodes a single slice/array/map element, which may
+// itself be a nested struct addressed with segment as its name prefix.
+func decodeElement(elem reflect.Value, segment string, values url.Values, errs *DecodeError) {
+	if elem.Kind() == reflect.Pointer {
+		if elem.IsNil() {
+			elem.Set(reflect.New(elem.Type().Elem()))
+		}
+		elem = elem.Elem()
+	}
+
+	if elem.Kind() == reflect.Struct && elem.Type() != reflect.TypeOf(time.Time{}) {
+		decodeStruct(elem, values, []string{segment}, errs)
+		return
+	}
+
+	raw, ok := values[segment]
+	if !ok || len(raw) == 0 {
+		return
+	}
+	if err := setValue(elem, raw[0], ""); err != nil {
+		*errs = append(*errs, &FieldError{Name: segment, Err: err})
+	}
+}
+
+// collectIndices returns the sorted, de-duplicated set of indices found
+// in keys of the form "name[N]" or "name[N].Sub...".
+func collectIndices(name string, values url.Values) []int {
+	prefix := name + "["
+	seen := map[int]bool{}
+	for k := range values {
+		idx, ok := indexedKey(k, prefix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(idx)
+		if err != nil {
+			continue
+		}
+		seen[n] = true
+	}
+
+	indices := make([]int, 0, len(seen))
+	for i := range seen {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// collectMapKeys returns the sorted, de-duplicated set of keys found in
+// keys of the form "name[key]" or "name[key].Sub...".
+func collectMapKeys(name string, values url.Values) []string {
+	prefix := name + "["
+	seen := map[string]bool{}
+	for k := range values {
+		idx, ok := indexedKey(k, prefix)
+		if !ok {
+			continue
+		}
+		seen[idx] = true
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// indexedKey reports whether key starts with prefix (e.g. "name[") and,
+// if so, returns the text between that prefix and the next "]" (e.g.
+// "123" for "name[123].Street1").
+func indexedKey(key, prefix string) (string, bool) {
+	if !strings.HasPrefix(key, prefix) {
+		return "", false
+	}
+	rest := key[len(prefix):]
+	end := strings.IndexByte(rest, ']')
+	if end < 0 {
+		return "", false
+	}
+	return rest[:end], true
+}
+
+// setValue parses raw into rf according to rf's kind, following the
+// format tag for time.Time fields (defaulting to time.RFC3339).
+func setValue(rf reflect.Value, raw string, format string) error {
+	if rf.Type() == reflect.TypeOf(time.Time{}) {
+		layout := format
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, raw)
+		if err != nil {
+			return err
+		}
+		rf.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch rf.Kind() {
+	case reflect.String:
+		rf.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		rf.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		rf.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		rf.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		rf.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported kind %s", rf.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestDecodeValues_collections(t *testing.T) {
+	type address struct {
+		Street1 string
+	}
+
+	t.Run("slice of structs", func(t *testing.T) {
+		var dst struct {
+			Addresses []address
+		}
+		err := DecodeValues(url.Values{
+			"Addresses[0].Street1": {"123 Test St"},
+			"Addresses[1].Street1": {"456 Other Ave"},
+		}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(dst.Addresses) != 2 || dst.Addresses[0].Street1 != "123 Test St" || dst.Addresses[1].Street1 != "456 Other Ave" {
+			t.Errorf("got %+v", dst.Addresses)
+		}
+	})
+
+	t.Run("slice of strings", func(t *testing.T) {
+		var dst struct {
+			Tags []string
+		}
+		err := DecodeValues(url.Values{
+			"Tags[0]": {"a"},
+			"Tags[2]": {"c"},
+		}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(dst.Tags) != 3 || dst.Tags[0] != "a" || dst.Tags[1] != "" || dst.Tags[2] != "c" {
+			t.Errorf("got %+v", dst.Tags)
+		}
+	})
+
+	t.Run("map of strings", func(t *testing.T) {
+		var dst struct {
+			Tags map[string]string
+		}
+		err := DecodeValues(url.Values{
+			"Tags[a]": {"1"},
+			"Tags[b]": {"2"},
+		}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dst.Tags["a"] != "1" || dst.Tags["b"] != "2" {
+			t.Errorf("got %+v", dst.Tags)
+		}
+	})
+
+	t.Run("huge index produces a FieldError instead of a giant allocation", func(t *testing.T) {
+		var dst struct {
+			Tags []string
+		}
+		err := DecodeValues(url.Values{"Tags[999999999]": {"x"}}, &dst)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		decErr, ok := err.(DecodeError)
+		if !ok || len(decErr) != 1 || decErr[0].Name != "Tags" {
+			t.Fatalf("got %#v", err)
+		}
+		if dst.Tags != nil {
+			t.Errorf("got Tags = %+v, want untouched", dst.Tags)
+		}
+	})
+
+	t.Run("[]byte field is not treated as an indexed collection", func(t *testing.T) {
+		var dst struct {
+			Avatar []byte
+		}
+		err := DecodeValues(url.Values{"Avatar": {"hello"}}, &dst)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		decErr, ok := err.(DecodeError)
+		if !ok || len(decErr) != 1 || decErr[0].Name != "Avatar" {
+			t.Fatalf("got %#v", err)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package form
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecode(t *testing.T) {
+	body := strings.NewReader(url.Values{"Name": {"Michael Scott"}, "Age": {"45"}}.Encode())
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var dst struct {
+		Name string
+		Age  int
+	}
+	if err := Decode(r, &dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := struct {
+		Name string
+		Age  int
+	}{"Michael Scott", 45}
+	if diff := cmp.Diff(want, dst); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDecodeValues(t *testing.T) {
+	type address struct {
+		Street1 string
+	}
+
+	t.Run("simple", func(t *testing.T) {
+		var dst struct {
+			Name string
+			Age  int
+		}
+		err := DecodeValues(url.Values{"Name": {"Michael Scott"}, "Age": {"45"}}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := struct {
+			Name string
+			Age  int
+		}{"Michael Scott", 45}
+		if diff := cmp.Diff(want, dst); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		var dst struct {
+			Name    string
+			Address address
+		}
+		err := DecodeValues(url.Values{"Name": {"Michael Scott"}, "Address.Street1": {"123 Test St"}}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dst.Address.Street1 != "123 Test St" {
+			t.Errorf("got Street1 = %q", dst.Address.Street1)
+		}
+	})
+
+	t.Run("nil pointer is allocated", func(t *testing.T) {
+		var dst struct {
+			Address *address
+		}
+		err := DecodeValues(url.Values{"Address.Street1": {"123 Test St"}}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dst.Address == nil || dst.Address.Street1 != "123 Test St" {
+			t.Errorf("got Address = %+v", dst.Address)
+		}
+	})
+
+	t.Run("name tag override", func(t *testing.T) {
+		var dst struct {
+			Street string `form:"name=street"`
+		}
+		err := DecodeValues(url.Values{"street": {"123 Test St"}}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dst.Street != "123 Test St" {
+			t.Errorf("got Street = %q", dst.Street)
+		}
+	})
+
+	t.Run("ignored field is skipped", func(t *testing.T) {
+		dst := struct {
+			Secret string `form:"-"`
+		}{Secret: "untouched"}
+		err := DecodeValues(url.Values{"Secret": {"hacked"}}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dst.Secret != "untouched" {
+			t.Errorf("ignored field was overwritten: %q", dst.Secret)
+		}
+	})
+
+	t.Run("time with format tag", func(t *testing.T) {
+		var dst struct {
+			Birthday time.Time `form:"format=2006-01-02"`
+		}
+		err := DecodeValues(url.Values{"Birthday": {"1978-03-15"}}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := time.Date(1978, 3, 15, 0, 0, 0, 0, time.UTC)
+		if !dst.Birthday.Equal(want) {
+			t.Errorf("got Birthday = %v, want %v", dst.Birthday, want)
+		}
+	})
+
+	t.Run("bad value produces a FieldError", func(t *testing.T) {
+		var dst struct {
+			Age int
+		}
+		err := DecodeValues(url.Values{"Age": {"not-a-number"}}, &dst)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		decErr, ok := err.(DecodeError)
+		if !ok || len(decErr) != 1 || decErr[0].Name != "Age" {
+			t.Fatalf("got %#v", err)
+		}
+	})
+
+	t.Run("missing keys are left untouched", func(t *testing.T) {
+		dst := struct {
+			Name string
+		}{Name: "default"}
+		err := DecodeValues(url.Values{}, &dst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dst.Name != "default" {
+			t.Errorf("got Name = %q", dst.Name)
+		}
+	})
+
+	t.Run("requires a pointer", func(t *testing.T) {
+		var dst struct{ Name string }
+		if err := DecodeValues(url.Values{}, dst); err == nil {
+			t.Fatal("expected an error for non-pointer dst")
+		}
+	})
+}
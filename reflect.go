@@ -27,92 +27,20 @@ func valueOf(v interface{}) reflect.Value {
 	return rv
 }
 
+// fields renders v into a flat slice of fields, one per leaf in its
+// reflect.VisibleFields tree (nested structs are dotted, e.g.
+// "Address.Street1"; slices/arrays/maps get one set of fields per
+// element, e.g. "Addresses[0].Street1"), using the package's default
+// Renderer. It is kept as the package-level entry point for backward
+// compatibility; form.New(opts...).Fields(v) is the configurable form.
+//
+// The reflective walk itself - which leaves are structs vs. collections
+// vs. ordinary fields, their tags, their default labels/types - only
+// depends on v's type and the Renderer's tag name, so it's precomputed
+// once per (type, tag name) pair and cached; only the live FieldByIndex
+// lookups happen on every call.
 func fields(v interface{}, names ...string) []field {
-	rv := valueOf(v)
-	if rv.Kind() != reflect.Struct {
-		// We can't really do much with a non-struct type. I suppose this
-		// could eventually support maps as well, but for now it does not.
-		panic("invalid value; only structs are supported")
-	}
-
-	t := rv.Type()
-	vFields := reflect.VisibleFields(t)
-	ret := make([]field, 0, len(vFields))
-	for _, tf := range vFields {
-		if !tf.IsExported() {
-			continue
-		}
-
-		rf := rv.FieldByIndex(tf.Index)
-		// If this is a nil pointer, create a new instance of the element.
-		if tf.Type.Kind() == reflect.Pointer && rf.IsNil() {
-			rf = reflect.Zero(tf.Type.Elem())
-		}
-
-		// If this is a struct it has nested fields we need to add. The
-		// simplest way to do this is to recursively call `fields` but
-		// to provide the name of this struct field to be added as a prefix
-		// to the fields.
-		// This does not apply to anonymous structs, because their fields are
-		// seen as "inlined".
-		if reflect.Indirect(rf).Kind() == reflect.Struct {
-			if !tf.Anonymous {
-				ret = append(ret, fields(rf.Interface(), append(names, tf.Name)...)...)
-			}
-			continue
-		}
-
-		// If we are still in this loop then we aren't dealing with a nested
-		// struct and need to add the field. First we check to see if the
-		// ignore tag is present, then we set default values, then finally
-		// we overwrite defaults with any provided tags.
-		tags, ignored := parseTags(tf.Tag.Get("form"))
-		if ignored {
-			continue
-		}
-		name := append(names, tf.Name)
-		f := field{
-			Name:        strings.Join(name, "."),
-			Label:       tf.Name,
-			Placeholder: tf.Name,
-			Type:        "text",
-			Value:       rf.Interface(),
-		}
-		f.applyTags(tags)
-		ret = append(ret, f)
-	}
-	return ret
-}
-
-func (f *field) applyTags(tags map[string]string) {
-	if v, ok := tags["name"]; ok {
-		f.Name = v
-	}
-	if v, ok := tags["label"]; ok {
-		f.Label = v
-		// DO NOT move this label check after the placeholder check or
-		// this will cause issues.
-		f.Placeholder = v
-	}
-	if v, ok := tags["placeholder"]; ok {
-		f.Placeholder = v
-	}
-	if v, ok := tags["type"]; ok {
-		f.Type = v
-	}
-	if v, ok := tags["id"]; ok {
-		f.ID = v
-	}
-	if v, ok := tags["footer"]; ok {
-		// Probably shouldn't be HTML but whatever.
-		f.Footer = template.HTML(v)
-	}
-	if v, ok := tags["class"]; ok {
-		f.Class = v
-	}
-	if v, ok := tags["readonly"]; ok {
-		f.ReadOnly = v == "true"
-	}
+	return defaultRenderer.fields(v, names...)
 }
 
 func parseTags(tags string) (map[string]string, bool) {
@@ -123,7 +51,10 @@ func parseTags(tags string) (map[string]string, bool) {
 	split := strings.Split(tags, ";")
 	ret := make(map[string]string, len(split))
 	for _, tag := range split {
-		kv := strings.Split(tag, "=")
+		// SplitN, not Split: rule lists like "validate=min=0" and
+		// "validate=pattern=/a=b/" have their own "=" signs that must
+		// stay part of the value.
+		kv := strings.SplitN(tag, "=", 2)
 		if len(kv) < 2 {
 			if kv[0] == "-" {
 				return nil, true
@@ -146,4 +77,13 @@ type field struct {
 	Value       interface{}
 	Footer      template.HTML
 	Class       string
+	Required    bool
+	Min         *float64
+	Max         *float64
+	MinLength   *int
+	MaxLength   *int
+	Pattern     string
+	Rules       []rule
+	Options     []string
+	Choices     []Option
 }
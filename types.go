@@ -0,0 +1,81 @@
+package form
+
+import (
+	"io"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	bytesType  = reflect.TypeOf([]byte(nil))
+	readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+)
+
+// Option is a single choice for a select, radio group, or other
+// enumerated field. Options come only from a discovered <Field>Options
+// method (see discoverOptions), which can give each choice its own
+// label; the options= tag fills field.Options with plain strings and
+// does not produce Option values.
+type Option struct {
+	Value string
+	Label string
+}
+
+// defaultType infers an HTML input type from a Go type, for fields that
+// don't specify one via the type= tag.
+func defaultType(t reflect.Type) string {
+	switch {
+	case t == timeType:
+		return "datetime-local"
+	case t.Kind() == reflect.Bool:
+		return "checkbox"
+	case isNumericKind(t.Kind()):
+		return "number"
+	case t == bytesType || t.Implements(readerType):
+		return "file"
+	default:
+		return "text"
+	}
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// discoverOptions looks for a method named name+"Options" on rv (trying
+// both value and pointer receivers) returning []Option, so choices like
+// country lists can be computed in Go instead of re-templated. It
+// returns nil if no such method exists.
+func discoverOptions(rv reflect.Value, name string) []Option {
+	methodName := name + "Options"
+
+	m := rv.MethodByName(methodName)
+	if !m.IsValid() {
+		var ptr reflect.Value
+		if rv.CanAddr() {
+			ptr = rv.Addr()
+		} else {
+			ptr = reflect.New(rv.Type())
+			ptr.Elem().Set(rv)
+		}
+		m = ptr.MethodByName(methodName)
+	}
+	if !m.IsValid() {
+		return nil
+	}
+
+	mt := m.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 || mt.Out(0) != reflect.TypeOf([]Option(nil)) {
+		return nil
+	}
+	out := m.Call(nil)
+	return out[0].Interface().([]Option)
+}
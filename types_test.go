@@ -0,0 +1,72 @@
+package form
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFields_typeInference(t *testing.T) {
+	t.Run("bool becomes checkbox", func(t *testing.T) {
+		got := fields(struct{ Active bool }{true})
+		if got[0].Type != "checkbox" {
+			t.Errorf("got Type = %q", got[0].Type)
+		}
+	})
+
+	t.Run("time.Time becomes datetime-local", func(t *testing.T) {
+		got := fields(struct{ Created time.Time }{})
+		if got[0].Type != "datetime-local" {
+			t.Errorf("got Type = %q", got[0].Type)
+		}
+	})
+
+	t.Run("numeric kinds become number", func(t *testing.T) {
+		got := fields(struct {
+			Age    int
+			Weight float64
+		}{})
+		if got[0].Type != "number" || got[1].Type != "number" {
+			t.Errorf("got %+v", got)
+		}
+	})
+
+	t.Run("[]byte becomes file", func(t *testing.T) {
+		got := fields(struct{ Avatar []byte }{})
+		if got[0].Type != "file" {
+			t.Errorf("got Type = %q", got[0].Type)
+		}
+	})
+
+	t.Run("explicit type tag wins", func(t *testing.T) {
+		got := fields(struct {
+			Active bool `form:"type=text"`
+		}{})
+		if got[0].Type != "text" {
+			t.Errorf("got Type = %q", got[0].Type)
+		}
+	})
+
+	t.Run("widget overrides type", func(t *testing.T) {
+		got := fields(struct {
+			Color string `form:"widget=select"`
+		}{})
+		if got[0].Type != "select" {
+			t.Errorf("got Type = %q", got[0].Type)
+		}
+	})
+}
+
+type country struct {
+	Code string
+}
+
+func (country) CodeOptions() []Option {
+	return []Option{{Value: "us", Label: "United States"}, {Value: "de", Label: "Germany"}}
+}
+
+func TestFields_discoveredOptions(t *testing.T) {
+	got := fields(country{})
+	if len(got[0].Choices) != 2 || got[0].Choices[0].Value != "us" {
+		t.Errorf("got Choices = %+v", got[0].Choices)
+	}
+}
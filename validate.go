@@ -0,0 +1,291 @@
+package form
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rule is a single parsed entry from a validate tag, e.g. "min=0" becomes
+// rule{Name: "min", Arg: "0"} and "required" becomes rule{Name: "required"}.
+type rule struct {
+	Name string
+	Arg  string
+}
+
+func parseRules(tag string) []rule {
+	parts := strings.Split(tag, ",")
+	ret := make([]rule, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		kv := strings.SplitN(p, "=", 2)
+		r := rule{Name: kv[0]}
+		if len(kv) == 2 {
+			r.Arg = kv[1]
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+// RuleFunc validates rv against arg, the raw text following "=" in a
+// validate tag entry (e.g. "18" for "min=18", empty for "required"). It
+// should return a descriptive error when rv is invalid.
+type RuleFunc func(rv reflect.Value, arg string) error
+
+var rules = map[string]RuleFunc{}
+
+func init() {
+	RegisterRule("required", ruleRequired)
+	RegisterRule("min", ruleMin)
+	RegisterRule("max", ruleMax)
+	RegisterRule("minlen", ruleMinLen)
+	RegisterRule("maxlen", ruleMaxLen)
+	RegisterRule("pattern", rulePattern)
+	RegisterRule("email", ruleEmail)
+	RegisterRule("url", ruleURL)
+	RegisterRule("in", ruleIn)
+}
+
+// RegisterRule adds or overrides a named validation rule. The built-in
+// rules (required, min, max, minlen, maxlen, pattern, email, url, in)
+// are registered through the same mechanism, so there is no special case
+// for them and domain rules can be added without forking the package.
+func RegisterRule(name string, fn RuleFunc) {
+	rules[name] = fn
+}
+
+// ValidationError collects the validation failures discovered by
+// Validate, keyed by the same dotted field names fields() produces. The
+// value for each key is the list of rule names that failed.
+type ValidationError map[string][]string
+
+func (e ValidationError) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %s", name, strings.Join(e[name], ", ")))
+	}
+	return "form: validation failed: " + strings.Join(parts, "; ")
+}
+
+// Validate walks v the same way fields() does and runs every validate
+// tag rule against the underlying value. It returns a ValidationError
+// when one or more rules fail, or nil if v is valid.
+func Validate(v any) error {
+	errs := ValidationError{}
+	validateValue(v, nil, errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateValue(v interface{}, names []string, errs ValidationError) {
+	rv := valueOf(v)
+	if rv.Kind() != reflect.Struct {
+		panic("invalid value; only structs are supported")
+	}
+
+	t := rv.Type()
+	for _, tf := range reflect.VisibleFields(t) {
+		if !tf.IsExported() {
+			continue
+		}
+
+		rf := fieldByIndexSafe(rv, tf.Index)
+		if tf.Type.Kind() == reflect.Pointer && rf.IsNil() {
+			rf = reflect.Zero(tf.Type.Elem())
+		}
+		indirect := reflect.Indirect(rf)
+
+		if indirect.Kind() == reflect.Struct && indirect.Type() != timeType {
+			if !tf.Anonymous {
+				validateValue(rf.Interface(), append(names, tf.Name), errs)
+			}
+			continue
+		}
+
+		tags, ignored := parseTags(tf.Tag.Get("form"))
+		if ignored {
+			continue
+		}
+
+		isCollection := (indirect.Kind() == reflect.Slice || indirect.Kind() == reflect.Array || indirect.Kind() == reflect.Map) && indirect.Type() != bytesType
+		if isCollection {
+			name := tf.Name
+			if v, ok := tags["name"]; ok {
+				name = v
+			}
+			validateCollection(indirect, name, names, errs)
+			continue
+		}
+
+		ruleTag, ok := tags["validate"]
+		if !ok {
+			continue
+		}
+
+		name := strings.Join(append(names, tf.Name), ".")
+		if v, ok := tags["name"]; ok {
+			name = v
+		}
+
+		for _, r := range parseRules(ruleTag) {
+			fn, ok := rules[r.Name]
+			if !ok {
+				continue
+			}
+			if err := fn(rf, r.Arg); err != nil {
+				errs[name] = append(errs[name], r.Name)
+			}
+		}
+	}
+}
+
+// validateCollection runs validate tag rules against each element of rv,
+// a slice, array, or map, naming elements the way sliceFields/mapFields
+// do (e.g. "Items[0].Name", "Tags[key]"). Only struct elements can carry
+// nested validate tags, so non-struct elements are skipped.
+func validateCollection(rv reflect.Value, name string, names []string, errs ValidationError) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			validateElement(rv.Index(i), fmt.Sprintf("%s[%d]", name, i), names, errs)
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			validateElement(rv.MapIndex(key), fmt.Sprintf("%s[%v]", name, key.Interface()), names, errs)
+		}
+	}
+}
+
+func validateElement(elem reflect.Value, segment string, names []string, errs ValidationError) {
+	if reflect.Indirect(elem).Kind() == reflect.Struct {
+		validateValue(elem.Interface(), append(names, segment), errs)
+	}
+}
+
+func ruleRequired(rv reflect.Value, _ string) error {
+	if rv.IsZero() {
+		return fmt.Errorf("is required")
+	}
+	return nil
+}
+
+func ruleMin(rv reflect.Value, arg string) error {
+	n, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return nil
+	}
+	if toFloat(rv) < n {
+		return fmt.Errorf("must be at least %v", n)
+	}
+	return nil
+}
+
+func ruleMax(rv reflect.Value, arg string) error {
+	n, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return nil
+	}
+	if toFloat(rv) > n {
+		return fmt.Errorf("must be at most %v", n)
+	}
+	return nil
+}
+
+func ruleMinLen(rv reflect.Value, arg string) error {
+	n, err := strconv.Atoi(arg)
+	if err != nil || rv.Kind() != reflect.String {
+		return nil
+	}
+	if len(rv.String()) < n {
+		return fmt.Errorf("must be at least %d characters", n)
+	}
+	return nil
+}
+
+func ruleMaxLen(rv reflect.Value, arg string) error {
+	n, err := strconv.Atoi(arg)
+	if err != nil || rv.Kind() != reflect.String {
+		return nil
+	}
+	if len(rv.String()) > n {
+		return fmt.Errorf("must be at most %d characters", n)
+	}
+	return nil
+}
+
+func rulePattern(rv reflect.Value, arg string) error {
+	if rv.Kind() != reflect.String {
+		return nil
+	}
+	re, err := regexp.Compile(strings.Trim(arg, "/"))
+	if err != nil {
+		return err
+	}
+	if !re.MatchString(rv.String()) {
+		return fmt.Errorf("does not match pattern %s", arg)
+	}
+	return nil
+}
+
+func ruleEmail(rv reflect.Value, _ string) error {
+	if rv.Kind() != reflect.String {
+		return nil
+	}
+	if _, err := mail.ParseAddress(rv.String()); err != nil {
+		return fmt.Errorf("is not a valid email address")
+	}
+	return nil
+}
+
+func ruleURL(rv reflect.Value, _ string) error {
+	if rv.Kind() != reflect.String {
+		return nil
+	}
+	u, err := url.ParseRequestURI(rv.String())
+	if err != nil || u.Scheme == "" {
+		return fmt.Errorf("is not a valid URL")
+	}
+	return nil
+}
+
+func ruleIn(rv reflect.Value, arg string) error {
+	if rv.Kind() != reflect.String {
+		return nil
+	}
+	for _, opt := range strings.Split(arg, "|") {
+		if rv.String() == opt {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", arg)
+}
+
+func toFloat(rv reflect.Value) float64 {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	default:
+		return 0
+	}
+}
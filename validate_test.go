@@ -0,0 +1,162 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid value passes", func(t *testing.T) {
+		arg := struct {
+			Name string `form:"validate=required,minlen=2,maxlen=10"`
+			Age  int    `form:"validate=min=0,max=120"`
+		}{"Michael", 45}
+		if err := Validate(arg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("required rejects zero value", func(t *testing.T) {
+		arg := struct {
+			Name string `form:"validate=required"`
+		}{}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		verr := err.(ValidationError)
+		if got := verr["Name"]; len(got) != 1 || got[0] != "required" {
+			t.Errorf("got %v", verr)
+		}
+	})
+
+	t.Run("min and max on numbers", func(t *testing.T) {
+		arg := struct {
+			Age int `form:"validate=min=18,max=65"`
+		}{Age: 90}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		verr := err.(ValidationError)
+		if got := verr["Age"]; len(got) != 1 || got[0] != "max" {
+			t.Errorf("got %v", verr)
+		}
+	})
+
+	t.Run("minlen and maxlen on strings", func(t *testing.T) {
+		arg := struct {
+			Name string `form:"validate=minlen=5"`
+		}{Name: "hi"}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("pattern", func(t *testing.T) {
+		arg := struct {
+			Code string `form:"validate=pattern=/^[A-Z]{3}$/"`
+		}{Code: "abc"}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("email", func(t *testing.T) {
+		arg := struct {
+			Email string `form:"validate=email"`
+		}{Email: "not-an-email"}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("in", func(t *testing.T) {
+		arg := struct {
+			Color string `form:"validate=in=red|green|blue"`
+		}{Color: "purple"}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("nested struct", func(t *testing.T) {
+		type address struct {
+			Street1 string `form:"validate=required"`
+		}
+		arg := struct {
+			Address address
+		}{}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		verr := err.(ValidationError)
+		if _, ok := verr["Address.Street1"]; !ok {
+			t.Errorf("got %v", verr)
+		}
+	})
+
+	t.Run("slice of structs", func(t *testing.T) {
+		type item struct {
+			Name string `form:"validate=required"`
+		}
+		arg := struct {
+			Items []item
+		}{
+			Items: []item{{Name: "widget"}, {}},
+		}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		verr := err.(ValidationError)
+		if got := verr["Items[1].Name"]; len(got) != 1 || got[0] != "required" {
+			t.Errorf("got %v", verr)
+		}
+		if _, ok := verr["Items[0].Name"]; ok {
+			t.Errorf("got %v, did not expect Items[0].Name to fail", verr)
+		}
+	})
+
+	t.Run("map of structs", func(t *testing.T) {
+		type item struct {
+			Name string `form:"validate=required"`
+		}
+		arg := struct {
+			Items map[string]item
+		}{
+			Items: map[string]item{"a": {}},
+		}
+		err := Validate(arg)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		verr := err.(ValidationError)
+		if _, ok := verr["Items[a].Name"]; !ok {
+			t.Errorf("got %v", verr)
+		}
+	})
+
+	t.Run("custom rule via RegisterRule", func(t *testing.T) {
+		RegisterRule("even", func(rv reflect.Value, _ string) error {
+			if rv.Int()%2 != 0 {
+				return fmt.Errorf("must be even")
+			}
+			return nil
+		})
+		defer delete(rules, "even")
+
+		arg := struct {
+			N int `form:"validate=even"`
+		}{N: 3}
+		if err := Validate(arg); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}